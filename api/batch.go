@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// batchMaxEntries 是一次批量请求最多包含的 repo 数
+	batchMaxEntries = 50
+	batchWorkers    = 4
+	// defaultBatchTimeout 是整个批量请求的超时，可以用 BATCH_TIMEOUT 覆盖
+	defaultBatchTimeout = 20 * time.Second
+)
+
+type BatchEntry struct {
+	Repo string `json:"repo"`
+	Name string `json:"name"`
+}
+
+type BatchResult struct {
+	Repo        string `json:"repo"`
+	Name        string `json:"name"`
+	Tag         string `json:"tag,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BatchLatestReleases 一次解析多个 repo 的最新 release，请求体为 [{"repo": ..., "name": ...}]，
+// 按顺序返回每一项的结果，单项失败或超时只影响该项
+func BatchLatestReleases(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		writePreflight(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		WriteError(w, http.StatusMethodNotAllowed, ErrBadMethod, "please use POST")
+		return
+	}
+	setCORSHeaders(w, r)
+	cfg, err := loadDefaultConfig()
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, ErrInternal, err.Error())
+		return
+	}
+	var entries []BatchEntry
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&entries); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrBadParam, fmt.Sprintf("please check your request body, err: %s", err))
+		return
+	}
+	if len(entries) > batchMaxEntries {
+		WriteError(w, http.StatusBadRequest, ErrBadParam, fmt.Sprintf("too many entries, at most %d", batchMaxEntries))
+		return
+	}
+	for i, e := range entries {
+		if len(e.Repo) > maxParamLength || len(e.Name) > maxParamLength {
+			WriteError(w, http.StatusBadRequest, ErrBadParam, fmt.Sprintf("please check entry %d, repo and name must be at most %d characters", i, maxParamLength))
+			return
+		}
+	}
+	// 每一项都可能请求 GitHub，按项数消耗令牌，和逐个请求 /api/download 一样，空的请求也算一次
+	cost := len(entries)
+	if cost == 0 {
+		cost = 1
+	}
+	if clientLimiter.rpm > 0 && cost > clientLimiter.rpm {
+		WriteError(w, http.StatusTooManyRequests, ErrRateLimited, fmt.Sprintf("too many entries, at most %d per minute on this deployment", clientLimiter.rpm))
+		return
+	}
+	if ok, wait := clientLimiter.AllowN(clientIP(r), cost); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		WriteError(w, http.StatusTooManyRequests, ErrRateLimited, "too many requests, please slow down")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), envDuration("BATCH_TIMEOUT", defaultBatchTimeout))
+	defer cancel()
+	results := make([]BatchResult, len(entries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < batchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j] = resolveBatchEntry(ctx, cfg, entries[j])
+			}
+		}()
+	}
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	WriteJson(w, results)
+}
+
+// resolveBatchEntry 和 /api/download 不带其它参数时一样，用同一个 resolver 选出 release 和 asset
+func resolveBatchEntry(ctx context.Context, cfg Config, e BatchEntry) BatchResult {
+	ret := BatchResult{Repo: e.Repo, Name: e.Name}
+	if err := ctx.Err(); err != nil {
+		ret.Error = "timeout"
+		return ret
+	}
+	rv, err := newResolver(cfg, e.Repo, Options{})
+	if err == nil {
+		err = rv.load(ctx, rv.opts.useLatest())
+	}
+	if err == nil {
+		err = rv.choose()
+	}
+	var res *Result
+	if err == nil {
+		ret.Tag = rv.release.TagName
+		res, err = rv.match(e.Name)
+	}
+	if err != nil {
+		var he *httpError
+		switch {
+		case ctx.Err() != nil:
+			ret.Error = "timeout"
+		case errors.As(err, &he):
+			ret.Error = he.msg
+		default:
+			ret.Error = err.Error()
+		}
+		return ret
+	}
+	ret.DownloadURL = res.DownloadURL
+	return ret
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// Version 在构建时通过 -ldflags "-X <module>/api.Version=..." 注入
+var Version string
+
+var startTime = time.Now()
+
+// appVersion 返回 Version，本地构建没有注入时为 dev
+func appVersion() string {
+	if Version == "" {
+		return "dev"
+	}
+	return Version
+}
+
+func HealthCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		writePreflight(w, r)
+		return
+	}
+	setCORSHeaders(w, r)
+	WriteJson(w, map[string]interface{}{
+		"status":     "ok",
+		"version":    appVersion(),
+		"go_version": runtime.Version(),
+		"uptime":     time.Since(startTime).Round(time.Second).String(),
+	})
+}